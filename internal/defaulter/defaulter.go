@@ -0,0 +1,218 @@
+// Package defaulter holds the logic for emitting Default() method bodies
+// from a protoreflect.MessageDescriptor. It exists so that the gogo-based
+// plugin (plugin/defaulter) and the protogen-based plugin
+// (cmd/protoc-gen-go-defaulter) produce identical output; neither plugin
+// should contain field-walking logic of its own.
+package defaulter
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	sensuproto "github.com/sensu/sensu-proto/protobuf"
+)
+
+// GetDefault returns the raw text of the sensuproto.default option on
+// field, and whether the option was set at all.
+func GetDefault(field protoreflect.FieldDescriptor) (string, bool) {
+	opts, ok := field.Options().(proto.Message)
+	if !ok {
+		return "", false
+	}
+	v := proto.GetExtension(opts, sensuproto.E_Default)
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return "", false
+	}
+	return s, true
+}
+
+// defaultMetadataField is the TypeMeta trigger field name used when a
+// file doesn't set sensuproto.metadata_field.
+const defaultMetadataField protoreflect.Name = "metadata"
+
+// stringExtension returns the value of the given string extension on
+// opts, and whether it was set at all.
+func stringExtension(opts interface{}, ext protoreflect.ExtensionType) (string, bool) {
+	m, ok := opts.(proto.Message)
+	if !ok {
+		return "", false
+	}
+	v := proto.GetExtension(m, ext)
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return "", false
+	}
+	return s, true
+}
+
+// GetMetadataField returns the TypeMeta trigger field name configured by
+// sensuproto.metadata_field on file, defaulting to "metadata" if unset.
+func GetMetadataField(file protoreflect.FileDescriptor) protoreflect.Name {
+	s, ok := stringExtension(file.Options(), sensuproto.E_MetadataField)
+	if !ok {
+		return defaultMetadataField
+	}
+	return protoreflect.Name(s)
+}
+
+// GetGroupVersion returns the literal group/version string configured
+// by sensuproto.group_version on file, and whether it was set at all.
+func GetGroupVersion(file protoreflect.FileDescriptor) (string, bool) {
+	return stringExtension(file.Options(), sensuproto.E_GroupVersion)
+}
+
+// GetKind returns the literal Kind string configured by sensuproto.kind
+// on msg, and whether it was set at all.
+func GetKind(msg protoreflect.MessageDescriptor) (string, bool) {
+	return stringExtension(msg.Options(), sensuproto.E_Kind)
+}
+
+// GoIdent is the set of callbacks a plugin must supply so that Lines can
+// turn descriptor information into Go source without needing to know
+// whether it is running under protoc-gen-gogo or protoc-gen-go.
+type GoIdent struct {
+	// FieldName returns the exported Go struct field name for field.
+	FieldName func(field protoreflect.FieldDescriptor) string
+
+	// EnumValue resolves token, the raw text of a sensuproto.default
+	// option on an enum field, to the Go-qualified identifier the
+	// plugin's code generator chose for that enum value. It returns an
+	// error if token does not name a value of field's enum type.
+	EnumValue func(field protoreflect.FieldDescriptor, token string) (string, error)
+}
+
+// Lines returns the statements that make up the body of
+// func (r *<GoName>) Default() { ... } for msg, using recv as the
+// receiver variable name. It returns an error if an enum field's
+// sensuproto.default option doesn't name a value of that enum.
+func Lines(g GoIdent, msg protoreflect.MessageDescriptor, recv string) ([]string, error) {
+	var lines []string
+
+	if meta := msg.Fields().ByName(GetMetadataField(msg.ParentFile())); meta != nil {
+		kind, ok := GetKind(msg)
+		if !ok {
+			kind = string(msg.Name())
+		}
+		lines = append(lines, fmt.Sprintf("%s.Kind = %q", recv, kind))
+
+		if groupVersion, ok := GetGroupVersion(msg.ParentFile()); ok {
+			lines = append(lines, fmt.Sprintf("%s.ApiVersion = %q", recv, groupVersion))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s.ApiVersion = SchemeGroupVersion.GroupVersionString()", recv))
+		}
+	}
+
+	fields := msg.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		fieldName := g.FieldName(field)
+
+		switch {
+		case field.IsMap():
+			if field.MapValue().Kind() == protoreflect.MessageKind {
+				lines = append(lines,
+					fmt.Sprintf("for _, v := range %s.%s {", recv, fieldName),
+					"if v != nil {",
+					"v.Default()",
+					"}",
+					"}",
+				)
+			}
+		case field.IsList() && (field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind):
+			lines = append(lines,
+				fmt.Sprintf("for i := range %s.%s {", recv, fieldName),
+				fmt.Sprintf("if %s.%s[i] != nil {", recv, fieldName),
+				fmt.Sprintf("%s.%s[i].Default()", recv, fieldName),
+				"}",
+				"}",
+			)
+		case field.IsList() && field.Kind() == protoreflect.EnumKind:
+			if def, ok := GetDefault(field); ok {
+				ident, err := g.EnumValue(field, def)
+				if err != nil {
+					return nil, err
+				}
+				// ident is "<qualified enum type>_<value name>"; strip
+				// the value suffix back off to get the slice's element
+				// type.
+				elemType := strings.TrimSuffix(ident, "_"+def)
+				lines = append(lines,
+					fmt.Sprintf("if %s.%s == nil {", recv, fieldName),
+					fmt.Sprintf("%s.%s = []%s{%s}", recv, fieldName, elemType, ident),
+					"}",
+				)
+			}
+		case field.IsList():
+			if def, ok := GetDefault(field); ok {
+				lines = append(lines,
+					fmt.Sprintf("if %s.%s == nil {", recv, fieldName),
+					fmt.Sprintf("%s.%s = []%s{%s}", recv, fieldName, goElemType(field.Kind()), literal(field, def)),
+					"}",
+				)
+			}
+		case field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind:
+			lines = append(lines,
+				fmt.Sprintf("if %s.%s != nil {", recv, fieldName),
+				fmt.Sprintf("%s.%s.Default()", recv, fieldName),
+				"}",
+			)
+		case field.Kind() == protoreflect.EnumKind:
+			if def, ok := GetDefault(field); ok {
+				ident, err := g.EnumValue(field, def)
+				if err != nil {
+					return nil, err
+				}
+				lines = append(lines, fmt.Sprintf("%s.%s = %s", recv, fieldName, ident))
+			}
+		default:
+			if def, ok := GetDefault(field); ok {
+				lines = append(lines, fmt.Sprintf("%s.%s = %s", recv, fieldName, literal(field, def)))
+			}
+		}
+	}
+
+	return lines, nil
+}
+
+// literal renders def, the raw text of a sensuproto.default option, as a
+// Go literal appropriate for field's type.
+func literal(field protoreflect.FieldDescriptor, def string) string {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return fmt.Sprintf("%q", def)
+	case protoreflect.BytesKind:
+		return fmt.Sprintf("[]byte(%q)", def)
+	}
+	return def
+}
+
+// goElemType returns the Go element type used for a repeated scalar
+// field of the given kind.
+func goElemType(kind protoreflect.Kind) string {
+	switch kind {
+	case protoreflect.StringKind:
+		return "string"
+	case protoreflect.BoolKind:
+		return "bool"
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return "int32"
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return "int64"
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return "uint32"
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "uint64"
+	case protoreflect.FloatKind:
+		return "float32"
+	case protoreflect.DoubleKind:
+		return "float64"
+	case protoreflect.BytesKind:
+		return "[]byte"
+	default:
+		return "interface{}"
+	}
+}
@@ -0,0 +1,416 @@
+package defaulter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	sensuproto "github.com/sensu/sensu-proto/protobuf"
+)
+
+// buildTestFile assembles a small, self-contained FileDescriptorProto
+// exercising every field shape Lines has to handle: scalar, enum
+// (singular and repeated), nested message (singular, repeated, and
+// map), and a self-referencing message field.
+func buildTestFile(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+
+	withDefault := func(token string) *descriptorpb.FieldOptions {
+		opts := &descriptorpb.FieldOptions{}
+		proto.SetExtension(opts, sensuproto.E_Default, token)
+		return opts
+	}
+
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test.proto"),
+		Package: proto.String("testpb"),
+		Syntax:  proto.String("proto3"),
+		EnumType: []*descriptorpb.EnumDescriptorProto{
+			{
+				Name: proto.String("Color"),
+				Value: []*descriptorpb.EnumValueDescriptorProto{
+					{Name: proto.String("UNKNOWN"), Number: proto.Int32(0)},
+					{Name: proto.String("RED"), Number: proto.Int32(1)},
+					{Name: proto.String("GREEN"), Number: proto.Int32(2)},
+				},
+			},
+		},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Inner"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:     proto.String("name"),
+						Number:   proto.Int32(1),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+						JsonName: proto.String("name"),
+						Options:  withDefault("inner-default"),
+					},
+				},
+			},
+			{
+				Name: proto.String("Outer"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:     proto.String("name"),
+						Number:   proto.Int32(1),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+						JsonName: proto.String("name"),
+						Options:  withDefault("outer-default"),
+					},
+					{
+						Name:     proto.String("count"),
+						Number:   proto.Int32(2),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(),
+						JsonName: proto.String("count"),
+						Options:  withDefault("5"),
+					},
+					{
+						Name:     proto.String("color"),
+						Number:   proto.Int32(3),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum(),
+						TypeName: proto.String(".testpb.Color"),
+						JsonName: proto.String("color"),
+						Options:  withDefault("RED"),
+					},
+					{
+						Name:     proto.String("inner"),
+						Number:   proto.Int32(4),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+						TypeName: proto.String(".testpb.Inner"),
+						JsonName: proto.String("inner"),
+					},
+					{
+						Name:     proto.String("inners"),
+						Number:   proto.Int32(5),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+						TypeName: proto.String(".testpb.Inner"),
+						JsonName: proto.String("inners"),
+					},
+					{
+						Name:     proto.String("inner_map"),
+						Number:   proto.Int32(6),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+						TypeName: proto.String(".testpb.Outer.InnerMapEntry"),
+						JsonName: proto.String("innerMap"),
+					},
+					{
+						Name:     proto.String("colors"),
+						Number:   proto.Int32(7),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum(),
+						TypeName: proto.String(".testpb.Color"),
+						JsonName: proto.String("colors"),
+						Options:  withDefault("GREEN"),
+					},
+					{
+						// Self-reference: Lines must not recurse through
+						// the cyclic type when walking fields, since it
+						// only ever emits one Default() call per field
+						// rather than inlining the referenced message.
+						Name:     proto.String("self"),
+						Number:   proto.Int32(8),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+						TypeName: proto.String(".testpb.Outer"),
+						JsonName: proto.String("self"),
+					},
+				},
+				NestedType: []*descriptorpb.DescriptorProto{
+					{
+						Name:    proto.String("InnerMapEntry"),
+						Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+						Field: []*descriptorpb.FieldDescriptorProto{
+							{
+								Name:     proto.String("key"),
+								Number:   proto.Int32(1),
+								Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+								Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+								JsonName: proto.String("key"),
+							},
+							{
+								Name:     proto.String("value"),
+								Number:   proto.Int32(2),
+								Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+								Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+								TypeName: proto.String(".testpb.Inner"),
+								JsonName: proto.String("value"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	fdesc, err := protodesc.NewFile(fd, nil)
+	if err != nil {
+		t.Fatalf("building test file descriptor: %v", err)
+	}
+	return fdesc
+}
+
+// withFileExtension builds a FileOptions with set applied to it, for
+// configuring sensuproto.metadata_field / sensuproto.group_version in
+// test fixtures.
+func withFileExtension(set func(*descriptorpb.FileOptions)) *descriptorpb.FileOptions {
+	opts := &descriptorpb.FileOptions{}
+	set(opts)
+	return opts
+}
+
+// withMessageExtension builds a MessageOptions with set applied to it,
+// for configuring sensuproto.kind in test fixtures.
+func withMessageExtension(set func(*descriptorpb.MessageOptions)) *descriptorpb.MessageOptions {
+	opts := &descriptorpb.MessageOptions{}
+	set(opts)
+	return opts
+}
+
+// buildMetaTestFile assembles a FileDescriptorProto exercising
+// GetMetadataField, GetGroupVersion, and GetKind: a single message named
+// Widget with a TypeMeta trigger field named metaField, configured by
+// fileOpts (sensuproto.metadata_field / sensuproto.group_version) and
+// messageOpts (sensuproto.kind).
+func buildMetaTestFile(t *testing.T, metaField string, fileOpts *descriptorpb.FileOptions, messageOpts *descriptorpb.MessageOptions) protoreflect.FileDescriptor {
+	t.Helper()
+
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("meta.proto"),
+		Package: proto.String("testpb"),
+		Syntax:  proto.String("proto3"),
+		Options: fileOpts,
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name:    proto.String("Widget"),
+				Options: messageOpts,
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:     proto.String(metaField),
+						Number:   proto.Int32(1),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+						JsonName: proto.String(metaField),
+					},
+				},
+			},
+		},
+	}
+
+	fdesc, err := protodesc.NewFile(fd, nil)
+	if err != nil {
+		t.Fatalf("building meta test file descriptor: %v", err)
+	}
+	return fdesc
+}
+
+// testIdent returns a GoIdent suitable for driving Lines in tests: Go
+// field names are just the proto name capitalized, and enum values are
+// rendered as testpb.<Enum>_<VALUE>.
+func testIdent() GoIdent {
+	return GoIdent{
+		FieldName: goFieldName,
+		EnumValue: func(field protoreflect.FieldDescriptor, token string) (string, error) {
+			v := field.Enum().Values().ByName(protoreflect.Name(token))
+			if v == nil {
+				return "", fmt.Errorf("%q is not a value of enum %s", token, field.Enum().Name())
+			}
+			return fmt.Sprintf("testpb.%s_%s", field.Enum().Name(), v.Name()), nil
+		},
+	}
+}
+
+func TestLines(t *testing.T) {
+	file := buildTestFile(t)
+
+	tests := []struct {
+		name    string
+		message string
+		recv    string
+		want    []string
+	}{
+		{
+			name:    "scalar default",
+			message: "Inner",
+			recv:    "r",
+			want: []string{
+				`r.Name = "inner-default"`,
+			},
+		},
+		{
+			name:    "scalar, enum, message, repeated, map, and cycle",
+			message: "Outer",
+			recv:    "o",
+			want: []string{
+				`o.Name = "outer-default"`,
+				`o.Count = 5`,
+				`o.Color = testpb.Color_RED`,
+				`if o.Inner != nil {`,
+				`o.Inner.Default()`,
+				`}`,
+				`for i := range o.Inners {`,
+				`if o.Inners[i] != nil {`,
+				`o.Inners[i].Default()`,
+				`}`,
+				`}`,
+				`for _, v := range o.InnerMap {`,
+				`if v != nil {`,
+				`v.Default()`,
+				`}`,
+				`}`,
+				`if o.Colors == nil {`,
+				`o.Colors = []testpb.Color{testpb.Color_GREEN}`,
+				`}`,
+				`if o.Self != nil {`,
+				`o.Self.Default()`,
+				`}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := file.Messages().ByName(protoreflect.Name(tt.message))
+			if msg == nil {
+				t.Fatalf("message %s not found in test file", tt.message)
+			}
+
+			got, err := Lines(testIdent(), msg, tt.recv)
+			if err != nil {
+				t.Fatalf("Lines() error = %v", err)
+			}
+
+			if !equalLines(got, tt.want) {
+				t.Errorf("Lines() =\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestLines_Metadata(t *testing.T) {
+	tests := []struct {
+		name        string
+		metaField   string
+		fileOpts    *descriptorpb.FileOptions
+		messageOpts *descriptorpb.MessageOptions
+		want        []string
+	}{
+		{
+			name:      "default metadata field, no kind or group_version override",
+			metaField: "metadata",
+			want: []string{
+				`r.Kind = "Widget"`,
+				`r.ApiVersion = SchemeGroupVersion.GroupVersionString()`,
+			},
+		},
+		{
+			name:      "configured metadata_field",
+			metaField: "type_meta",
+			fileOpts: withFileExtension(func(o *descriptorpb.FileOptions) {
+				proto.SetExtension(o, sensuproto.E_MetadataField, "type_meta")
+			}),
+			want: []string{
+				`r.Kind = "Widget"`,
+				`r.ApiVersion = SchemeGroupVersion.GroupVersionString()`,
+			},
+		},
+		{
+			name:      "sensuproto.kind set",
+			metaField: "metadata",
+			messageOpts: withMessageExtension(func(o *descriptorpb.MessageOptions) {
+				proto.SetExtension(o, sensuproto.E_Kind, "CustomKind")
+			}),
+			want: []string{
+				`r.Kind = "CustomKind"`,
+				`r.ApiVersion = SchemeGroupVersion.GroupVersionString()`,
+			},
+		},
+		{
+			name:      "sensuproto.group_version set",
+			metaField: "metadata",
+			fileOpts: withFileExtension(func(o *descriptorpb.FileOptions) {
+				proto.SetExtension(o, sensuproto.E_GroupVersion, "widgets/v1")
+			}),
+			want: []string{
+				`r.Kind = "Widget"`,
+				`r.ApiVersion = "widgets/v1"`,
+			},
+		},
+		{
+			name:      "no metadata field present",
+			metaField: "other",
+			want:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := buildMetaTestFile(t, tt.metaField, tt.fileOpts, tt.messageOpts)
+			msg := file.Messages().ByName("Widget")
+			if msg == nil {
+				t.Fatal("message Widget not found in test file")
+			}
+
+			// The trigger field Lines looks for is always "metadata"
+			// unless metadata_field overrides it, so a test file built
+			// with a differently-named field (and no override) must
+			// produce no Kind/ApiVersion lines at all.
+			got, err := Lines(testIdent(), msg, "r")
+			if err != nil {
+				t.Fatalf("Lines() error = %v", err)
+			}
+			if !equalLines(got, tt.want) {
+				t.Errorf("Lines() =\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestLines_InvalidEnumDefault(t *testing.T) {
+	file := buildTestFile(t)
+	msg := file.Messages().ByName("Outer")
+
+	ident := testIdent()
+	ident.EnumValue = func(field protoreflect.FieldDescriptor, token string) (string, error) {
+		return "", fmt.Errorf("%q is not a value of enum %s", token, field.Enum().Name())
+	}
+
+	if _, err := Lines(ident, msg, "o"); err == nil {
+		t.Fatal("Lines() error = nil, want error for unresolvable enum default")
+	}
+}
+
+// goFieldName mimics protoc-gen-go's CamelCase field naming: each
+// underscore-separated segment of the proto name is capitalized and
+// joined, so "inner_map" becomes "InnerMap".
+func goFieldName(field protoreflect.FieldDescriptor) string {
+	segments := strings.Split(string(field.Name()), "_")
+	for i, s := range segments {
+		segments[i] = strings.ToUpper(s[:1]) + s[1:]
+	}
+	return strings.Join(segments, "")
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+// Package gogodesc bridges gogo's protoc-gen-gogo descriptor types to
+// google.golang.org/protobuf's protoreflect descriptors, so that plugins
+// built on protoc-gen-gogo (plugin/defaulter, plugin/validator) can drive
+// field-walking logic written once against protoreflect
+// (internal/defaulter, internal/validator) instead of duplicating it
+// against gogo's own descriptor API.
+package gogodesc
+
+import (
+	gogoproto "github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Files converts every gogo FileDescriptorProto in protoFiles into its
+// google.golang.org/protobuf equivalent and registers them into a
+// protoregistry.Files. protoc always lists files in dependency order, so
+// each one can be registered as soon as its dependencies are.
+func Files(protoFiles []*descriptor.FileDescriptorProto) (*protoregistry.Files, error) {
+	files := &protoregistry.Files{}
+	for _, gogoFD := range protoFiles {
+		fd, err := toDescriptorProto(gogoFD)
+		if err != nil {
+			return nil, err
+		}
+		f, err := protodesc.NewFile(fd, files)
+		if err != nil {
+			return nil, err
+		}
+		if err := files.RegisterFile(f); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// toDescriptorProto converts a gogo FileDescriptorProto into its
+// google.golang.org/protobuf equivalent. The two are wire-compatible
+// with each other, so a marshal/unmarshal round trip is sufficient.
+func toDescriptorProto(in *descriptor.FileDescriptorProto) (*descriptorpb.FileDescriptorProto, error) {
+	b, err := gogoproto.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	var out descriptorpb.FileDescriptorProto
+	if err := proto.Unmarshal(b, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// FieldByNumber returns the gogo field descriptor on fields with the
+// given field number, which is how protoreflect.FieldDescriptor values
+// are matched back up to the gogo descriptors a plugin's Go-naming
+// helpers (e.g. generator.Generator.GetFieldName) need.
+func FieldByNumber(fields []*descriptor.FieldDescriptorProto, number protoreflect.FieldNumber) *descriptor.FieldDescriptorProto {
+	for _, f := range fields {
+		if protoreflect.FieldNumber(f.GetNumber()) == number {
+			return f
+		}
+	}
+	return nil
+}
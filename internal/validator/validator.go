@@ -0,0 +1,266 @@
+// Package validator holds the logic for emitting Validate() method
+// bodies from a protoreflect.MessageDescriptor. It exists so that the
+// gogo-based plugin (plugin/validator) can be driven from a single
+// source of truth instead of walking gogo descriptors directly, the
+// same way plugin/defaulter is driven by internal/defaulter.
+package validator
+
+import (
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	sensuproto "github.com/sensu/sensu-proto/protobuf"
+)
+
+// boolExtension returns the value of the given bool extension on opts,
+// and whether it was set at all.
+func boolExtension(opts interface{}, ext protoreflect.ExtensionType) (bool, bool) {
+	m, ok := opts.(proto.Message)
+	if !ok || !proto.HasExtension(m, ext) {
+		return false, false
+	}
+	v := proto.GetExtension(m, ext)
+	b, ok := v.(bool)
+	if !ok {
+		return false, false
+	}
+	return b, true
+}
+
+// intExtension returns the value of the given int64 extension on opts,
+// and whether it was set at all. Unlike the string extensions, 0 is a
+// meaningful value for min_len/max_len, so presence has to be checked
+// with HasExtension rather than by comparing against the zero value.
+func intExtension(opts interface{}, ext protoreflect.ExtensionType) (int64, bool) {
+	m, ok := opts.(proto.Message)
+	if !ok || !proto.HasExtension(m, ext) {
+		return 0, false
+	}
+	i, ok := proto.GetExtension(m, ext).(int64)
+	if !ok {
+		return 0, false
+	}
+	return i, true
+}
+
+// stringExtension returns the value of the given string extension on
+// opts, and whether it was set at all.
+func stringExtension(opts interface{}, ext protoreflect.ExtensionType) (string, bool) {
+	m, ok := opts.(proto.Message)
+	if !ok {
+		return "", false
+	}
+	v := proto.GetExtension(m, ext)
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return "", false
+	}
+	return s, true
+}
+
+// GetRequired reports whether field carries sensuproto.required = true.
+func GetRequired(field protoreflect.FieldDescriptor) bool {
+	required, _ := boolExtension(field.Options(), sensuproto.E_Required)
+	return required
+}
+
+// GetMinLen returns the value of the sensuproto.min_len option on field,
+// and whether it was set at all.
+func GetMinLen(field protoreflect.FieldDescriptor) (int64, bool) {
+	return intExtension(field.Options(), sensuproto.E_MinLen)
+}
+
+// GetMaxLen returns the value of the sensuproto.max_len option on field,
+// and whether it was set at all.
+func GetMaxLen(field protoreflect.FieldDescriptor) (int64, bool) {
+	return intExtension(field.Options(), sensuproto.E_MaxLen)
+}
+
+// GetPattern returns the sensuproto.pattern option on field, and whether
+// it was set at all.
+func GetPattern(field protoreflect.FieldDescriptor) (string, bool) {
+	return stringExtension(field.Options(), sensuproto.E_Pattern)
+}
+
+// GetMin returns the literal text of the sensuproto.min option on field,
+// and whether it was set at all.
+func GetMin(field protoreflect.FieldDescriptor) (string, bool) {
+	return stringExtension(field.Options(), sensuproto.E_Min)
+}
+
+// GetMax returns the literal text of the sensuproto.max option on field,
+// and whether it was set at all.
+func GetMax(field protoreflect.FieldDescriptor) (string, bool) {
+	return stringExtension(field.Options(), sensuproto.E_Max)
+}
+
+// GoIdent is the set of callbacks a plugin must supply so that Lines can
+// turn descriptor information into Go source without needing to know
+// whether it is running under protoc-gen-gogo or protoc-gen-go.
+type GoIdent struct {
+	// FieldName returns the exported Go struct field name for field.
+	FieldName func(field protoreflect.FieldDescriptor) string
+
+	// FmtPkg returns the identifier to use for the "fmt" package,
+	// registering it as an import the first time it's called.
+	FmtPkg func() string
+}
+
+// PatternVar validates pattern's regex syntax and registers a
+// package-level `var ... = regexp.MustCompile(...)` declaration for it,
+// returning the Go identifier of that variable. It is a plugin callback,
+// rather than logic that lives in Lines, because naming the variable and
+// registering the regexp import are both generator-specific concerns.
+type PatternVar func(fieldName, pattern string) (string, error)
+
+// Lines returns the statements that make up the body of
+// func (r *<GoName>) Validate() error { ...; return nil } for msg, using
+// recv as the receiver variable name. It returns an error if a pattern
+// constraint's regex fails to compile.
+func Lines(g GoIdent, patternVar PatternVar, msg protoreflect.MessageDescriptor, recv string) ([]string, error) {
+	var lines []string
+
+	fields := msg.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		fieldName := g.FieldName(field)
+		access := fmt.Sprintf("%s.%s", recv, fieldName)
+
+		fieldLines, err := fieldLines(g, patternVar, field, fieldName, access)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, fieldLines...)
+	}
+
+	return lines, nil
+}
+
+func fieldLines(g GoIdent, patternVar PatternVar, field protoreflect.FieldDescriptor, fieldName, access string) ([]string, error) {
+	errorf := func(msg string) string {
+		return fmt.Sprintf("return %s.Errorf(%q)", g.FmtPkg(), msg)
+	}
+
+	switch {
+	case field.IsMap():
+		if field.MapValue().Kind() != protoreflect.MessageKind {
+			return nil, nil
+		}
+		return []string{
+			fmt.Sprintf("for _, v := range %s {", access),
+			"if err := v.Validate(); err != nil {",
+			"return err",
+			"}",
+			"}",
+		}, nil
+
+	case field.IsList() && field.Kind() == protoreflect.MessageKind:
+		return []string{
+			fmt.Sprintf("for _, v := range %s {", access),
+			"if err := v.Validate(); err != nil {",
+			"return err",
+			"}",
+			"}",
+		}, nil
+
+	case field.Kind() == protoreflect.MessageKind:
+		var lines []string
+		if GetRequired(field) {
+			lines = append(lines,
+				fmt.Sprintf("if %s == nil {", access),
+				errorf(fieldName+" is required"),
+				"}",
+			)
+		}
+		lines = append(lines,
+			fmt.Sprintf("if %s != nil {", access),
+			fmt.Sprintf("if err := %s.Validate(); err != nil {", access),
+			"return err",
+			"}",
+			"}",
+		)
+		return lines, nil
+
+	case field.IsList():
+		// Repeated scalar fields carry no constraints today.
+		return nil, nil
+	}
+
+	var lines []string
+
+	if GetRequired(field) {
+		lines = append(lines,
+			fmt.Sprintf("if %s {", zeroCheck(field, access)),
+			errorf(fieldName+" is required"),
+			"}",
+		)
+	}
+
+	if field.Kind() == protoreflect.StringKind {
+		if minLen, ok := GetMinLen(field); ok {
+			lines = append(lines,
+				fmt.Sprintf("if len(%s) < %s {", access, strconv.FormatInt(minLen, 10)),
+				errorf(fmt.Sprintf("%s must be at least %d characters", fieldName, minLen)),
+				"}",
+			)
+		}
+		if maxLen, ok := GetMaxLen(field); ok {
+			lines = append(lines,
+				fmt.Sprintf("if len(%s) > %s {", access, strconv.FormatInt(maxLen, 10)),
+				errorf(fmt.Sprintf("%s must be at most %d characters", fieldName, maxLen)),
+				"}",
+			)
+		}
+		if pattern, ok := GetPattern(field); ok {
+			name, err := patternVar(fieldName, pattern)
+			if err != nil {
+				return nil, err
+			}
+			lines = append(lines,
+				fmt.Sprintf("if !%s.MatchString(%s) {", name, access),
+				errorf(fieldName+" does not match pattern "+pattern),
+				"}",
+			)
+		}
+		return lines, nil
+	}
+
+	if min, ok := GetMin(field); ok {
+		lines = append(lines,
+			fmt.Sprintf("if %s < %s {", access, min),
+			errorf(fieldName+" must be at least "+min),
+			"}",
+		)
+	}
+	if max, ok := GetMax(field); ok {
+		lines = append(lines,
+			fmt.Sprintf("if %s > %s {", access, max),
+			errorf(fieldName+" must be at most "+max),
+			"}",
+		)
+	}
+
+	return lines, nil
+}
+
+// zeroCheck returns the Go expression that tests whether access (a field
+// access expression) holds its zero value, matched to field's kind so
+// bool and bytes fields get a type-correct comparison instead of the
+// bare `== 0` that only works for numeric and enum fields.
+func zeroCheck(field protoreflect.FieldDescriptor, access string) string {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return access + ` == ""`
+	case protoreflect.BytesKind:
+		return `len(` + access + `) == 0`
+	case protoreflect.BoolKind:
+		return access + ` == false`
+	default:
+		// Numeric scalars and enums are all comparable to the untyped
+		// constant 0.
+		return access + ` == 0`
+	}
+}
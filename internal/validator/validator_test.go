@@ -0,0 +1,295 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	sensuproto "github.com/sensu/sensu-proto/protobuf"
+)
+
+// buildTestFile assembles a small, self-contained FileDescriptorProto
+// exercising every constraint and field shape Lines has to handle: a
+// string field with required+min_len+pattern all set together, a scalar
+// field with min/max, a required bool field, a required singular
+// message field, a repeated message field, and a map-of-message field.
+func buildTestFile(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+
+	withOpts := func(set func(*descriptorpb.FieldOptions)) *descriptorpb.FieldOptions {
+		opts := &descriptorpb.FieldOptions{}
+		set(opts)
+		return opts
+	}
+
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test.proto"),
+		Package: proto.String("testpb"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Inner"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:     proto.String("name"),
+						Number:   proto.Int32(1),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+						JsonName: proto.String("name"),
+					},
+				},
+			},
+			{
+				Name: proto.String("Outer"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:     proto.String("name"),
+						Number:   proto.Int32(1),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+						JsonName: proto.String("name"),
+						Options: withOpts(func(o *descriptorpb.FieldOptions) {
+							proto.SetExtension(o, sensuproto.E_Required, true)
+							proto.SetExtension(o, sensuproto.E_MinLen, int64(3))
+							proto.SetExtension(o, sensuproto.E_Pattern, "^[a-z]+$")
+						}),
+					},
+					{
+						Name:     proto.String("count"),
+						Number:   proto.Int32(2),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(),
+						JsonName: proto.String("count"),
+						Options: withOpts(func(o *descriptorpb.FieldOptions) {
+							proto.SetExtension(o, sensuproto.E_Min, "1")
+							proto.SetExtension(o, sensuproto.E_Max, "10")
+						}),
+					},
+					{
+						Name:     proto.String("flag"),
+						Number:   proto.Int32(3),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum(),
+						JsonName: proto.String("flag"),
+						Options: withOpts(func(o *descriptorpb.FieldOptions) {
+							proto.SetExtension(o, sensuproto.E_Required, true)
+						}),
+					},
+					{
+						Name:     proto.String("inner"),
+						Number:   proto.Int32(4),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+						TypeName: proto.String(".testpb.Inner"),
+						JsonName: proto.String("inner"),
+						Options: withOpts(func(o *descriptorpb.FieldOptions) {
+							proto.SetExtension(o, sensuproto.E_Required, true)
+						}),
+					},
+					{
+						Name:     proto.String("inners"),
+						Number:   proto.Int32(5),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+						TypeName: proto.String(".testpb.Inner"),
+						JsonName: proto.String("inners"),
+					},
+					{
+						Name:     proto.String("inner_map"),
+						Number:   proto.Int32(6),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+						TypeName: proto.String(".testpb.Outer.InnerMapEntry"),
+						JsonName: proto.String("innerMap"),
+					},
+				},
+				NestedType: []*descriptorpb.DescriptorProto{
+					{
+						Name:    proto.String("InnerMapEntry"),
+						Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+						Field: []*descriptorpb.FieldDescriptorProto{
+							{
+								Name:     proto.String("key"),
+								Number:   proto.Int32(1),
+								Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+								Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+								JsonName: proto.String("key"),
+							},
+							{
+								Name:     proto.String("value"),
+								Number:   proto.Int32(2),
+								Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+								Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+								TypeName: proto.String(".testpb.Inner"),
+								JsonName: proto.String("value"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	fdesc, err := protodesc.NewFile(fd, nil)
+	if err != nil {
+		t.Fatalf("building test file descriptor: %v", err)
+	}
+	return fdesc
+}
+
+// testIdent returns a GoIdent suitable for driving Lines in tests: Go
+// field names are just the proto name capitalized, and fmt is never
+// aliased.
+func testIdent() GoIdent {
+	return GoIdent{
+		FieldName: goFieldName,
+		FmtPkg:    func() string { return "fmt" },
+	}
+}
+
+// goFieldName mimics protoc-gen-go's CamelCase field naming: each
+// underscore-separated segment of the proto name is capitalized and
+// joined, so "inner_map" becomes "InnerMap".
+func goFieldName(field protoreflect.FieldDescriptor) string {
+	segments := strings.Split(string(field.Name()), "_")
+	for i, s := range segments {
+		segments[i] = strings.ToUpper(s[:1]) + s[1:]
+	}
+	return strings.Join(segments, "")
+}
+
+// testPatternVar names a pattern variable after its field, mirroring the
+// naming scheme a real plugin would use without needing a message type
+// to qualify it.
+func testPatternVar(fieldName, pattern string) (string, error) {
+	return fieldName + "Pattern", nil
+}
+
+func TestLines(t *testing.T) {
+	file := buildTestFile(t)
+	msg := file.Messages().ByName("Outer")
+	if msg == nil {
+		t.Fatal("message Outer not found in test file")
+	}
+
+	want := []string{
+		`if r.Name == "" {`,
+		`return fmt.Errorf("Name is required")`,
+		`}`,
+		`if len(r.Name) < 3 {`,
+		`return fmt.Errorf("Name must be at least 3 characters")`,
+		`}`,
+		`if !NamePattern.MatchString(r.Name) {`,
+		`return fmt.Errorf("Name does not match pattern ^[a-z]+$")`,
+		`}`,
+		`if r.Count < 1 {`,
+		`return fmt.Errorf("Count must be at least 1")`,
+		`}`,
+		`if r.Count > 10 {`,
+		`return fmt.Errorf("Count must be at most 10")`,
+		`}`,
+		`if r.Flag == false {`,
+		`return fmt.Errorf("Flag is required")`,
+		`}`,
+		`if r.Inner == nil {`,
+		`return fmt.Errorf("Inner is required")`,
+		`}`,
+		`if r.Inner != nil {`,
+		`if err := r.Inner.Validate(); err != nil {`,
+		`return err`,
+		`}`,
+		`}`,
+		`for _, v := range r.Inners {`,
+		`if err := v.Validate(); err != nil {`,
+		`return err`,
+		`}`,
+		`}`,
+		`for _, v := range r.InnerMap {`,
+		`if err := v.Validate(); err != nil {`,
+		`return err`,
+		`}`,
+		`}`,
+	}
+
+	got, err := Lines(testIdent(), testPatternVar, msg, "r")
+	if err != nil {
+		t.Fatalf("Lines() error = %v", err)
+	}
+
+	if !equalLines(got, want) {
+		t.Errorf("Lines() =\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestLines_NoConstraints(t *testing.T) {
+	file := buildTestFile(t)
+	msg := file.Messages().ByName("Inner")
+	if msg == nil {
+		t.Fatal("message Inner not found in test file")
+	}
+
+	got, err := Lines(testIdent(), testPatternVar, msg, "r")
+	if err != nil {
+		t.Fatalf("Lines() error = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Lines() = %v, want no lines for an unconstrained message", got)
+	}
+}
+
+func TestLines_InvalidPattern(t *testing.T) {
+	file := buildTestFile(t)
+	msg := file.Messages().ByName("Outer")
+
+	badPattern := func(fieldName, pattern string) (string, error) {
+		return "", errors.New("invalid pattern")
+	}
+
+	if _, err := Lines(testIdent(), badPattern, msg, "r"); err == nil {
+		t.Fatal("Lines() error = nil, want error for a rejected pattern")
+	}
+}
+
+func TestZeroCheck(t *testing.T) {
+	file := buildTestFile(t)
+	msg := file.Messages().ByName("Outer")
+
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{field: "name", want: `r.Name == ""`},
+		{field: "flag", want: `r.Flag == false`},
+		{field: "count", want: `r.Count == 0`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			field := msg.Fields().ByName(protoreflect.Name(tt.field))
+			if field == nil {
+				t.Fatalf("field %s not found", tt.field)
+			}
+			access := "r." + strings.ToUpper(tt.field[:1]) + tt.field[1:]
+			if got := zeroCheck(field, access); got != tt.want {
+				t.Errorf("zeroCheck() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
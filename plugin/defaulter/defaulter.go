@@ -2,44 +2,49 @@
 // for types, satisfying the Defaulter interface. If a default value for
 // a protobuf field is specified, the value given will be assigned to the
 // field in the struct and represented so that it can be parsed by Go and
-// assigned to the appropriate type. Because of complexity issues it can
-// only support basic type assignments like string, bool, and integers.
+// assigned to the appropriate type. Message, repeated, and map fields are
+// defaulted recursively by calling their own Default() method.
+//
+// github.com/gogo/protobuf/protoc-gen-gogo/generator is deprecated
+// upstream in favor of google.golang.org/protobuf/compiler/protogen.
+// This package is kept for projects still generating with
+// protoc-gen-gogo; new projects should prefer
+// cmd/protoc-gen-go-defaulter, which generates the same Default()
+// methods on top of protogen.
 package defaulter
 
 import (
+	"fmt"
 	"strings"
 
-	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
 	"github.com/gogo/protobuf/protoc-gen-gogo/generator"
-	sensuproto "github.com/sensu/sensu-proto/protobuf"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	coredefaulter "github.com/sensu/sensu-proto/internal/defaulter"
+	"github.com/sensu/sensu-proto/internal/gogodesc"
 )
 
 func init() {
 	generator.RegisterPlugin(NewDefaulter())
 }
 
-// GetDefault returns the default value for a field as a string.
-func GetDefault(field *descriptor.FieldDescriptorProto) *string {
-	if field == nil {
-		return nil
-	}
-
-	if field.Options != nil {
-		v, err := proto.GetExtension(field.Options, sensuproto.E_Default)
-		if err == nil && v.(*string) != nil {
-			return (v.(*string))
-		}
-	}
-
-	return nil
-}
-
 // Plugin is the default plugin.
 type Plugin struct {
 	*generator.Generator
 	generator.PluginImports
 	messages []*generator.Descriptor
+
+	// files is the protoregistry.Files built from the current protoc
+	// invocation, used to drive internal/defaulter.
+	files *protoregistry.Files
+
+	// generated tracks the full names of messages a Default() method
+	// has already been emitted for, since a nested message type can be
+	// reached more than once while walking a file.
+	generated map[protoreflect.FullName]bool
 }
 
 // NewDefaulter creates a new Defaulter generator
@@ -61,62 +66,108 @@ func (p *Plugin) Init(g *generator.Generator) {
 func (p *Plugin) Generate(file *generator.FileDescriptor) {
 	p.PluginImports = generator.NewPluginImports(p.Generator)
 	p.messages = make([]*generator.Descriptor, 0)
+	if p.generated == nil {
+		p.generated = make(map[protoreflect.FullName]bool)
+	}
+
+	if p.files == nil {
+		files, err := gogodesc.Files(p.Generator.Request.GetProtoFile())
+		if err != nil {
+			p.Fail("defaulter: converting descriptors for", file.GetName(), ":", err.Error())
+			return
+		}
+		p.files = files
+	}
+	files := p.files
 
 	for _, message := range file.Messages() {
-		// Default()
-		// Generates the Default() function for the type.
+		p.generateMessage(files, file.GetPackage(), message)
+	}
+}
 
-		// e.g. Rule
-		baseTypeName := generator.CamelCaseSlice(message.TypeName())
+// generateMessage emits the Default() method for message, which belongs
+// to the proto package pkg.
+func (p *Plugin) generateMessage(files *protoregistry.Files, pkg string, message *generator.Descriptor) {
+	// e.g. Rule
+	baseTypeName := generator.CamelCaseSlice(message.TypeName())
 
-		// "r" for use in func(r *Rule)
-		typeShort := strings.ToLower(string(baseTypeName[0]))
-		// Gives a newline
-		p.P()
+	// "r" for use in func(r *Rule)
+	typeShort := strings.ToLower(string(baseTypeName[0]))
 
-		// func (r *Rule) Default() {
-		p.P(`func (`, typeShort, `*`, baseTypeName, `) Default() {`)
+	typeName := strings.Join(message.TypeName(), ".")
+	if pkg != "" {
+		typeName = pkg + "." + typeName
+	}
+	fullName := protoreflect.FullName(typeName)
+	if p.generated[fullName] {
+		return
+	}
+	p.generated[fullName] = true
 
-		// Indent
-		p.In()
+	desc, err := files.FindDescriptorByName(fullName)
+	if err != nil {
+		p.Fail("defaulter: looking up", string(fullName), ":", err.Error())
+		return
+	}
+	msg, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		p.Fail("defaulter:", string(fullName), "is not a message")
+		return
+	}
 
-		//
-		if meta := message.GetFieldDescriptor("metadata"); meta != nil {
-			// this goes into every type.
+	ident := coredefaulter.GoIdent{
+		FieldName: func(field protoreflect.FieldDescriptor) string {
+			gogoField := gogodesc.FieldByNumber(message.Field, field.Number())
+			return p.GetFieldName(message, gogoField)
+		},
+		EnumValue: func(field protoreflect.FieldDescriptor, token string) (string, error) {
+			gogoField := gogodesc.FieldByNumber(message.Field, field.Number())
+			return p.enumValueIdent(gogoField, token)
+		},
+	}
 
-			// r.Kind = "Role"
-			p.P(typeShort, `.Kind = "`, *message.Name, `"`)
+	lines, err := coredefaulter.Lines(ident, msg, typeShort)
+	if err != nil {
+		p.Fail("defaulter:", err.Error())
+		return
+	}
 
-			// r.ApiVersion = SchemeGroupVersion.GroupVersionString()
-			// (when executed by Go): r.ApiVersion = rbac.sensu.io/v2alpha1
-			p.P(typeShort, `.ApiVersion = SchemeGroupVersion.GroupVersionString()`)
-		}
+	// Gives a newline
+	p.P()
 
-		// Now do field specific defaults. -->
-		for _, field := range message.Field {
-			// e.g. Namespace
-			fieldName := p.GetFieldName(message, field)
-
-			// GetDefault(field) -> *"somevalue"
-			if sensuDefault := GetDefault(field); sensuDefault != nil {
-				defaultValue := *sensuDefault
-
-				if field.IsString() {
-					// string namespace = 1 [(sensuproto.default = "default")]
-					// r.Namespace = "default";
-					p.P(typeShort, `.`, fieldName, `= "`, defaultValue, `"`)
-				} else if field.IsScalar() {
-					// integer version = 1 [(sensuproto.default = 1)];
-					// r.Version = 1
-					p.P(typeShort, `.`, fieldName, `= `, defaultValue)
-				}
-			}
-		}
+	// func (r *Rule) Default() {
+	p.P(`func (`, typeShort, `*`, baseTypeName, `) Default() {`)
+
+	// Indent
+	p.In()
+	for _, line := range lines {
+		p.P(line)
+	}
+	// Unindent
+	p.Out()
+	p.P(`}`)
+	// Gives a newline
+	p.P()
+}
 
-		// Unindent
-		p.Out()
-		p.P(`}`)
-		// Gives a newline
-		p.P()
+// enumValueIdent resolves token, the raw text of a sensuproto.default
+// option on field, to the Go-qualified identifier protoc-gen-gogo chose
+// for that enum value, registering the value's package as an import if
+// it isn't already one.
+func (p *Plugin) enumValueIdent(field *descriptor.FieldDescriptorProto, token string) (string, error) {
+	if field == nil {
+		return "", fmt.Errorf("field descriptor not found")
+	}
+	obj := p.ObjectNamed(field.GetTypeName())
+	enum, ok := obj.(*generator.EnumDescriptor)
+	if !ok {
+		return "", fmt.Errorf("%s is not an enum field", field.GetName())
+	}
+	for _, v := range enum.Value {
+		if v.GetName() == token {
+			p.PluginImports.NewImport(string(enum.GoImportPath()))
+			return p.TypeName(obj) + "_" + token, nil
+		}
 	}
+	return "", fmt.Errorf("%q is not a value of enum %s", token, p.TypeName(obj))
 }
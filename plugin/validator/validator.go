@@ -0,0 +1,186 @@
+// Package validator is a gogoproto plugin to generate Validate() methods
+// for types, checking the constraints declared by sensuproto field
+// options (min_len, max_len, pattern, min, max, required). It mirrors
+// the structure of plugin/defaulter: descriptors are bridged to
+// protoreflect via internal/gogodesc and the field-walking logic itself
+// lives in internal/validator, so this plugin and any future
+// protogen-based validator stay in sync the same way the two defaulter
+// plugins do.
+//
+// This gives sensu-proto users the standard "generated defaults +
+// generated validation" pair without pulling in protoc-gen-validate.
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/protobuf/protoc-gen-gogo/generator"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/sensu/sensu-proto/internal/gogodesc"
+	corevalidator "github.com/sensu/sensu-proto/internal/validator"
+)
+
+func init() {
+	generator.RegisterPlugin(NewValidator())
+}
+
+// Plugin is the validator plugin.
+type Plugin struct {
+	*generator.Generator
+	generator.PluginImports
+	messages []*generator.Descriptor
+
+	// files is the protoregistry.Files built from the current protoc
+	// invocation, used to drive internal/validator.
+	files *protoregistry.Files
+
+	// fmtImport and regexpImport are registered lazily, the first time
+	// the current file actually needs fmt.Errorf or regexp.MustCompile,
+	// so files with no constrained fields don't gain unused imports.
+	fmtImport    generator.Single
+	regexpImport generator.Single
+
+	// patternVars accumulates the package-level `var ... = regexp.MustCompile(...)`
+	// declarations for the current file's sensuproto.pattern constraints,
+	// emitted once after every message's Validate() method so patterns
+	// are compiled once at package init instead of on every call.
+	patternVars []string
+}
+
+// NewValidator creates a new Validator generator.
+func NewValidator() *Plugin {
+	return &Plugin{}
+}
+
+// Name returns the name of the plugin.
+func (p *Plugin) Name() string {
+	return "validator"
+}
+
+// Init initializes the plugin with the given generator.
+func (p *Plugin) Init(g *generator.Generator) {
+	p.Generator = g
+}
+
+// Generate the output for this plugin.
+func (p *Plugin) Generate(file *generator.FileDescriptor) {
+	p.PluginImports = generator.NewPluginImports(p.Generator)
+	p.messages = make([]*generator.Descriptor, 0)
+	p.fmtImport = nil
+	p.regexpImport = nil
+	p.patternVars = nil
+
+	if p.files == nil {
+		files, err := gogodesc.Files(p.Generator.Request.GetProtoFile())
+		if err != nil {
+			p.Fail("validator: converting descriptors for", file.GetName(), ":", err.Error())
+			return
+		}
+		p.files = files
+	}
+
+	pkg := file.GetPackage()
+	for _, message := range file.Messages() {
+		p.generateMessage(pkg, message)
+	}
+
+	for _, v := range p.patternVars {
+		p.P(v)
+	}
+}
+
+// generateMessage emits the Validate() method for message, which belongs
+// to the proto package pkg.
+func (p *Plugin) generateMessage(pkg string, message *generator.Descriptor) {
+	// e.g. Rule
+	baseTypeName := generator.CamelCaseSlice(message.TypeName())
+
+	// "r" for use in func(r *Rule)
+	typeShort := strings.ToLower(string(baseTypeName[0]))
+
+	typeName := strings.Join(message.TypeName(), ".")
+	if pkg != "" {
+		typeName = pkg + "." + typeName
+	}
+
+	desc, err := p.files.FindDescriptorByName(protoreflect.FullName(typeName))
+	if err != nil {
+		p.Fail("validator: looking up", typeName, ":", err.Error())
+		return
+	}
+	msg, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		p.Fail("validator:", typeName, "is not a message")
+		return
+	}
+
+	ident := corevalidator.GoIdent{
+		FieldName: func(field protoreflect.FieldDescriptor) string {
+			gogoField := gogodesc.FieldByNumber(message.Field, field.Number())
+			return p.GetFieldName(message, gogoField)
+		},
+		FmtPkg: p.fmtPkg,
+	}
+
+	lines, err := corevalidator.Lines(ident, func(fieldName, pattern string) (string, error) {
+		return p.patternVar(message, fieldName, pattern)
+	}, msg, typeShort)
+	if err != nil {
+		p.Fail("validator:", err.Error())
+		return
+	}
+
+	// Gives a newline
+	p.P()
+
+	// func (r *Rule) Validate() error {
+	p.P(`func (`, typeShort, `*`, baseTypeName, `) Validate() error {`)
+	p.In()
+	for _, line := range lines {
+		p.P(line)
+	}
+	p.P(`return nil`)
+	p.Out()
+	p.P(`}`)
+	// Gives a newline
+	p.P()
+}
+
+// fmtPkg registers "fmt" as an import the first time it's needed and
+// returns the package identifier to use in generated code.
+func (p *Plugin) fmtPkg() string {
+	if p.fmtImport == nil {
+		p.fmtImport = p.NewImport("fmt")
+	}
+	return p.fmtImport.Use()
+}
+
+// regexpPkg registers "regexp" as an import the first time it's needed
+// and returns the package identifier to use in generated code.
+func (p *Plugin) regexpPkg() string {
+	if p.regexpImport == nil {
+		p.regexpImport = p.NewImport("regexp")
+	}
+	return p.regexpImport.Use()
+}
+
+// patternVar validates pattern's regex syntax at codegen time, so a
+// typo in sensuproto.pattern fails protoc instead of panicking on every
+// Validate() call, registers a package-level `var ... = regexp.MustCompile(...)`
+// declaration for it, and returns the Go identifier of that variable.
+func (p *Plugin) patternVar(message *generator.Descriptor, fieldName, pattern string) (string, error) {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return "", fmt.Errorf("invalid pattern %q: %s", pattern, err)
+	}
+	name := generator.CamelCaseSlice(message.TypeName()) + fieldName + "Pattern"
+	p.patternVars = append(p.patternVars,
+		fmt.Sprintf("var %s = %s.MustCompile(%s)", name, p.regexpPkg(), strconv.Quote(pattern)),
+	)
+	return name, nil
+}